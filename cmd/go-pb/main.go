@@ -0,0 +1,124 @@
+// Copyright 2021 Ilia Frenkel. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE.txt file.
+
+// Command go-pb runs the go-pb server, serving the HTTP API and, if
+// configured, the gRPC API concurrently on separate ports.
+package main
+
+import (
+	"log"
+	"net"
+	"os"
+
+	"github.com/iliafrenkel/go-pb/src/api"
+	"github.com/iliafrenkel/go-pb/src/api/auth"
+	usersqldb "github.com/iliafrenkel/go-pb/src/api/auth/sqldb"
+	grpcapi "github.com/iliafrenkel/go-pb/src/api/grpc"
+	"github.com/iliafrenkel/go-pb/src/api/http"
+	"github.com/iliafrenkel/go-pb/src/api/paste/sqldb"
+	"github.com/iliafrenkel/go-pb/src/mail"
+	"github.com/iliafrenkel/go-pb/src/store/kv"
+	flags "github.com/jessevdk/go-flags"
+	"google.golang.org/grpc"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// options holds all the command line / environment variable options
+// understood by the go-pb server.
+type options struct {
+	HTTPAddr  string `long:"http-addr" env:"HTTP_ADDR" default:"127.0.0.1:8080" description:"address to serve the HTTP API on"`
+	GRPCAddr  string `long:"grpc-addr" env:"GRPC_ADDR" default:"" description:"address to serve the gRPC API on, leave empty to disable"`
+	DBDsn     string `long:"db-dsn" env:"DB_DSN" description:"postgres connection string"`
+	Secret    string `long:"secret" env:"SECRET" description:"JWT signing secret"`
+	RedisAddr string `long:"redis-addr" env:"REDIS_ADDR" default:"" description:"address of the Redis/Valkey instance backing sessions, rate limiting and view counts, leave empty to disable"`
+
+	URL          string `long:"url" env:"URL" default:"http://127.0.0.1:8080" description:"public URL of this service, used to build OAuth callback and verification links"`
+	Issuer       string `long:"issuer" env:"ISSUER" default:"go-pb" description:"value of the JWT \"iss\" claim"`
+	EnableDirect bool   `long:"enable-direct-auth" env:"ENABLE_DIRECT_AUTH" description:"enable the username/password auth provider"`
+
+	GithubCID     string `long:"github-cid" env:"GITHUB_CID" description:"GitHub OAuth2 client ID, leave empty to disable the provider"`
+	GithubCSecret string `long:"github-csecret" env:"GITHUB_CSECRET" description:"GitHub OAuth2 client secret"`
+	GoogleCID     string `long:"google-cid" env:"GOOGLE_CID" description:"Google OAuth2 client ID, leave empty to disable the provider"`
+	GoogleCSecret string `long:"google-csecret" env:"GOOGLE_CSECRET" description:"Google OAuth2 client secret"`
+
+	SMTPHost     string `long:"smtp-host" env:"SMTP_HOST" description:"SMTP server host, leave empty to disable verification and password reset emails"`
+	SMTPPort     int    `long:"smtp-port" env:"SMTP_PORT" default:"587" description:"SMTP server port"`
+	SMTPUsername string `long:"smtp-username" env:"SMTP_USERNAME" description:"SMTP username, leave empty to connect without authentication"`
+	SMTPPassword string `long:"smtp-password" env:"SMTP_PASSWORD" description:"SMTP password"`
+	SMTPFrom     string `long:"smtp-from" env:"SMTP_FROM" description:"address used in the \"From\" header of outgoing emails"`
+}
+
+func main() {
+	var opts options
+	if _, err := flags.Parse(&opts); err != nil {
+		os.Exit(1)
+	}
+
+	db, err := gorm.Open(postgres.Open(opts.DBDsn), &gorm.Config{})
+	if err != nil {
+		log.Fatalf("failed to connect to the database: %v", err)
+	}
+
+	svc, err := sqldb.New(sqldb.SvcOptions{DBConnection: db, DBAutoMigrate: true})
+	if err != nil {
+		log.Fatalf("failed to create paste service: %v", err)
+	}
+
+	userStore, err := usersqldb.New(usersqldb.SvcOptions{DBConnection: db, DBAutoMigrate: true})
+	if err != nil {
+		log.Fatalf("failed to create user store: %v", err)
+	}
+
+	usrSvc := &auth.UserService{UserStore: userStore, VerifyURL: opts.URL + "/auth/verify"}
+	if opts.SMTPHost != "" {
+		mailer, err := mail.New(mail.MailOptions{
+			Host:     opts.SMTPHost,
+			Port:     opts.SMTPPort,
+			Username: opts.SMTPUsername,
+			Password: opts.SMTPPassword,
+			From:     opts.SMTPFrom,
+		})
+		if err != nil {
+			log.Fatalf("failed to create mailer: %v", err)
+		}
+		usrSvc.Mailer = mailer
+	}
+
+	if opts.GRPCAddr != "" {
+		go serveGRPC(opts.GRPCAddr, svc, usrSvc, opts.Secret)
+	}
+
+	srv := http.New(svc, usrSvc, http.AuthOptions{
+		Secret:        opts.Secret,
+		URL:           opts.URL,
+		Issuer:        opts.Issuer,
+		EnableDirect:  opts.EnableDirect,
+		GithubCID:     opts.GithubCID,
+		GithubCSecret: opts.GithubCSecret,
+		GoogleCID:     opts.GoogleCID,
+		GoogleCSecret: opts.GoogleCSecret,
+	})
+	srv.Mailer = usrSvc.Mailer
+	if opts.RedisAddr != "" {
+		srv.KV = kv.NewRedis(opts.RedisAddr, kv.RedisOptions{})
+	}
+	log.Fatal(srv.ListenAndServe(opts.HTTPAddr))
+}
+
+// serveGRPC starts the gRPC server and blocks until it stops or fails.
+func serveGRPC(addr string, svc api.PasteService, usrSvc *auth.UserService, secret string) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatalf("failed to listen on %s: %v", addr, err)
+	}
+
+	s := grpc.NewServer(grpc.UnaryInterceptor(grpcapi.AuthInterceptor(secret, usrSvc)))
+	grpcapi.RegisterServer(s, grpcapi.New(svc))
+
+	log.Println("gRPC server listening on ", addr)
+	if err := s.Serve(lis); err != nil {
+		log.Fatalf("gRPC server stopped: %v", err)
+	}
+}
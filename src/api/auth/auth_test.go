@@ -6,8 +6,40 @@ import (
 	"testing"
 
 	"github.com/iliafrenkel/go-pb/src/api"
+	"github.com/iliafrenkel/go-pb/src/api/role"
+	"github.com/iliafrenkel/go-pb/src/mail"
 )
 
+// MockMailer is an in-memory mail.Mailer used to test the code paths that
+// send verification and password reset emails without talking to a real
+// SMTP server.
+type MockMailer struct {
+	mu   sync.Mutex
+	sent []mockMail
+}
+
+type mockMail struct {
+	Template string
+	To       string
+	Data     interface{}
+}
+
+func (m *MockMailer) Send(to, subject, body string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, mockMail{Template: subject, To: to, Data: body})
+	return nil
+}
+
+func (m *MockMailer) SendTemplate(name string, to string, data interface{}) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, mockMail{Template: name, To: to, Data: data})
+	return nil
+}
+
+var _ mail.Mailer = (*MockMailer)(nil)
+
 var usrSvc *UserService
 var tokenSecret = "5TEdWbDmxZ2ASXcMinBYwGi66vHiU9rq"
 
@@ -36,6 +68,12 @@ func (s MockStore) Find(usr api.User) (*api.User, error) {
 		if usr.ID != 0 && u.ID == usr.ID {
 			return u, nil
 		}
+		if usr.VerifyToken != "" && u.VerifyToken == usr.VerifyToken {
+			return u, nil
+		}
+		if usr.ResetToken != "" && u.ResetToken == usr.ResetToken {
+			return u, nil
+		}
 	}
 
 	return nil, nil
@@ -231,4 +269,145 @@ func Test_ValidateToken(t *testing.T) {
 		t.Errorf("Token validation failed: %s - %#v", inf.Token, v)
 
 	}
-}
\ No newline at end of file
+}
+
+func Test_CreateUserRequiresVerification(t *testing.T) {
+	t.Parallel()
+	store := new(MockStore)
+	store.users = make(map[int64]*api.User)
+	store.usersLock = &sync.RWMutex{}
+	mailer := new(MockMailer)
+	svc := &UserService{UserStore: store, Mailer: mailer, VerifyURL: "https://pb.example.com/auth/verify"}
+
+	usr := api.UserRegister{
+		Username:   "unverified",
+		Email:      "unverified@example.com",
+		Password:   "12345",
+		RePassword: "12345",
+	}
+	if err := svc.Create(usr); err != nil {
+		t.Fatalf("Failed to create a user: %v", err)
+	}
+
+	if len(mailer.sent) != 1 || mailer.sent[0].Template != "mail-verify.go.txt" {
+		t.Errorf("Expected a verification email to be sent, got: %#v", mailer.sent)
+	}
+
+	// Logging in before verifying the email must fail.
+	_, err := svc.Authenticate(api.UserLogin{Username: usr.Username, Password: usr.Password}, tokenSecret)
+	if err == nil {
+		t.Errorf("Authentication succeeded for an unverified user")
+	}
+
+	u, _ := store.Find(api.User{Username: usr.Username})
+	if u == nil {
+		t.Fatalf("Failed to find the newly created user")
+	}
+	if err := svc.Verify(u.VerifyToken); err != nil {
+		t.Fatalf("Failed to verify user: %v", err)
+	}
+
+	// Logging in after verifying the email must succeed.
+	inf, err := svc.Authenticate(api.UserLogin{Username: usr.Username, Password: usr.Password}, tokenSecret)
+	if err != nil {
+		t.Errorf("Failed to authenticate a verified user: %v", err)
+	}
+	if inf.Token == "" {
+		t.Errorf("Authentication succeeded but returned an empty token")
+	}
+}
+
+func Test_PasswordReset(t *testing.T) {
+	t.Parallel()
+	store := new(MockStore)
+	store.users = make(map[int64]*api.User)
+	store.usersLock = &sync.RWMutex{}
+	mailer := new(MockMailer)
+	svc := &UserService{UserStore: store, Mailer: mailer, VerifyURL: "https://pb.example.com/auth/reset-password"}
+
+	usr := api.UserRegister{
+		Username:   "resetme",
+		Email:      "resetme@example.com",
+		Password:   "12345",
+		RePassword: "12345",
+	}
+	if err := svc.Create(usr); err != nil {
+		t.Fatalf("Failed to create a user: %v", err)
+	}
+	if err := svc.Verify(func() string {
+		u, _ := store.Find(api.User{Username: usr.Username})
+		return u.VerifyToken
+	}()); err != nil {
+		t.Fatalf("Failed to verify user: %v", err)
+	}
+
+	if err := svc.RequestPasswordReset(usr.Email); err != nil {
+		t.Fatalf("Failed to request a password reset: %v", err)
+	}
+	if len(mailer.sent) != 2 || mailer.sent[1].Template != "mail-reset-password.go.txt" {
+		t.Errorf("Expected a password reset email to be sent, got: %#v", mailer.sent)
+	}
+
+	u, _ := store.Find(api.User{Username: usr.Username})
+	if u == nil || u.ResetToken == "" {
+		t.Fatalf("Expected a reset token to be stored on the user")
+	}
+
+	if err := svc.ConfirmPasswordReset(u.ResetToken, "new-password"); err != nil {
+		t.Fatalf("Failed to confirm the password reset: %v", err)
+	}
+
+	// The old password must no longer work, the new one must.
+	if _, err := svc.Authenticate(api.UserLogin{Username: usr.Username, Password: usr.Password}, tokenSecret); err == nil {
+		t.Errorf("Authentication succeeded with the old password after a reset")
+	}
+	if _, err := svc.Authenticate(api.UserLogin{Username: usr.Username, Password: "new-password"}, tokenSecret); err != nil {
+		t.Errorf("Failed to authenticate with the new password: %v", err)
+	}
+}
+
+func Test_SetRole(t *testing.T) {
+	t.Parallel()
+	store := new(MockStore)
+	store.users = make(map[int64]*api.User)
+	store.usersLock = &sync.RWMutex{}
+	svc := &UserService{UserStore: store}
+
+	usr := api.UserRegister{
+		Username:   "promoteme",
+		Email:      "promoteme@example.com",
+		Password:   "12345",
+		RePassword: "12345",
+	}
+	if err := svc.Create(usr); err != nil {
+		t.Fatalf("Failed to create a user: %v", err)
+	}
+	u, _ := store.Find(api.User{Username: usr.Username})
+	if u == nil {
+		t.Fatalf("Failed to find the newly created user")
+	}
+	if u.Role != role.Guest {
+		t.Fatalf("Expected a newly created user to default to the %q role, got %q", role.Guest, u.Role)
+	}
+
+	if err := svc.SetRole(u.ID, role.Admin); err != nil {
+		t.Fatalf("Failed to set role: %v", err)
+	}
+
+	u, _ = store.Find(api.User{Username: usr.Username})
+	if u == nil || u.Role != role.Admin {
+		t.Errorf("Expected the user's role to be %q, got %q", role.Admin, u.Role)
+	}
+}
+
+func Test_SetRoleUnknownUser(t *testing.T) {
+	t.Parallel()
+	store := new(MockStore)
+	store.users = make(map[int64]*api.User)
+	store.usersLock = &sync.RWMutex{}
+	svc := &UserService{UserStore: store}
+
+	if err := svc.SetRole(9999, role.Admin); err == nil {
+		t.Errorf("Expected setting the role of a non-existent user to fail")
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2021 Ilia Frenkel. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE.txt file.
+
+// Package sqldb provides an implementation of auth.UserStore that uses a
+// database as a storage, see src/api/paste/sqldb for the equivalent for
+// pastes.
+package sqldb
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/iliafrenkel/go-pb/src/api"
+	"gorm.io/gorm"
+)
+
+// SvcOptions contains all the options needed to create an instance of
+// UserStore.
+type SvcOptions struct {
+	// Open database connection.
+	// For sqlite it can be opened on either a file name or `file::memory:?cache=shared`
+	// to use temporary database in memory (ex. for testing).
+	DBConnection *gorm.DB
+	//
+	DBAutoMigrate bool
+}
+
+// UserStore stores all the users in a database and implements the
+// auth.UserStore interface.
+type UserStore struct {
+	db      *gorm.DB
+	Options SvcOptions
+}
+
+// New returns a new UserStore.
+func New(opts SvcOptions) (*UserStore, error) {
+	var s UserStore
+	s.Options = opts
+	db := opts.DBConnection
+
+	if s.Options.DBAutoMigrate {
+		db.AutoMigrate(&api.User{})
+	}
+	s.db = db
+
+	return &s, nil
+}
+
+// Store creates usr if it doesn't have an ID yet, otherwise it updates the
+// existing row with the same ID.
+func (s *UserStore) Store(usr api.User) error {
+	if s.db == nil {
+		return errors.New("Store: no database connection")
+	}
+	if err := s.db.Save(&usr).Error; err != nil {
+		return fmt.Errorf("Store: database error: %w", err)
+	}
+
+	return nil
+}
+
+// Find looks up a user by whichever of usr's fields is set, trying ID,
+// Username, Email, VerifyToken and ResetToken in that order. It returns
+// nil, nil if no user matches.
+func (s *UserStore) Find(usr api.User) (*api.User, error) {
+	if s.db == nil {
+		return nil, errors.New("Find: no database connection")
+	}
+
+	var found api.User
+	q := s.db
+	switch {
+	case usr.ID != 0:
+		q = q.Where("id = ?", usr.ID)
+	case usr.Username != "":
+		q = q.Where("username = ?", usr.Username)
+	case usr.Email != "":
+		q = q.Where("email = ?", usr.Email)
+	case usr.VerifyToken != "":
+		q = q.Where("verify_token = ?", usr.VerifyToken)
+	case usr.ResetToken != "":
+		q = q.Where("reset_token = ?", usr.ResetToken)
+	default:
+		return nil, nil
+	}
+
+	err := q.First(&found).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Find: database error: %w", err)
+	}
+
+	return &found, nil
+}
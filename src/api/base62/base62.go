@@ -0,0 +1,20 @@
+// Copyright 2021 Ilia Frenkel. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE.txt file.
+
+// Package base62 encodes paste IDs into short, URL-friendly strings.
+package base62
+
+import "strconv"
+
+// Encode returns the base 36 (digits and lowercase letters) string
+// representation of n.
+func Encode(n uint64) string {
+	return strconv.FormatUint(n, 36)
+}
+
+// Decode parses a string previously produced by Encode back into its
+// numeric paste ID.
+func Decode(s string) (uint64, error) {
+	return strconv.ParseUint(s, 36, 64)
+}
@@ -0,0 +1,87 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	pastev1 "github.com/iliafrenkel/go-pb/pkg/go/gen/paste/v1"
+	"github.com/iliafrenkel/go-pb/src/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mockPasteService is a minimal api.PasteService used to exercise the
+// ownership check in Server.Delete without a real database.
+type mockPasteService struct {
+	paste   *api.Paste
+	deleted bool
+}
+
+func (m *mockPasteService) Paste(id int64) (*api.Paste, error)    { return m.paste, nil }
+func (m *mockPasteService) Create(p *api.Paste) error             { return nil }
+func (m *mockPasteService) Delete(id int64) error                 { m.deleted = true; return nil }
+func (m *mockPasteService) DeleteAsAdmin(id int64) error          { m.deleted = true; return nil }
+func (m *mockPasteService) List(uid int64) []api.Paste            { return nil }
+func (m *mockPasteService) ListAll(offset, limit int) []api.Paste { return nil }
+
+var _ api.PasteService = (*mockPasteService)(nil)
+
+func Test_Delete_OwnerCanDeleteTheirOwnPaste(t *testing.T) {
+	t.Parallel()
+	owner := &api.User{ID: 1, Username: "owner"}
+	svc := &mockPasteService{paste: &api.Paste{ID: 42, UserID: owner.ID}}
+	s := New(svc)
+	ctx := context.WithValue(context.Background(), userContextKey{}, owner)
+
+	if _, err := s.Delete(ctx, &pastev1.DeleteRequest{Id: 42}); err != nil {
+		t.Errorf("expected the owner to be able to delete their own paste, got %v", err)
+	}
+	if !svc.deleted {
+		t.Errorf("expected Delete to be called for the owner's own paste")
+	}
+}
+
+func Test_Delete_RejectsDeletingAnotherUsersPaste(t *testing.T) {
+	t.Parallel()
+	owner := &api.User{ID: 1, Username: "owner"}
+	attacker := &api.User{ID: 2, Username: "attacker"}
+	svc := &mockPasteService{paste: &api.Paste{ID: 42, UserID: owner.ID}}
+	s := New(svc)
+	ctx := context.WithValue(context.Background(), userContextKey{}, attacker)
+
+	_, err := s.Delete(ctx, &pastev1.DeleteRequest{Id: 42})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected %s for a user deleting another user's paste, got %v", codes.PermissionDenied, err)
+	}
+	if svc.deleted {
+		t.Errorf("Delete must not be called when the caller doesn't own the paste")
+	}
+}
+
+func Test_Delete_RejectsUnauthenticatedRequestForOwnedPaste(t *testing.T) {
+	t.Parallel()
+	owner := &api.User{ID: 1, Username: "owner"}
+	svc := &mockPasteService{paste: &api.Paste{ID: 42, UserID: owner.ID}}
+	s := New(svc)
+
+	_, err := s.Delete(context.Background(), &pastev1.DeleteRequest{Id: 42})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected %s for an unauthenticated request against an owned paste, got %v", codes.PermissionDenied, err)
+	}
+	if svc.deleted {
+		t.Errorf("Delete must not be called without authentication when the paste has an owner")
+	}
+}
+
+func Test_Delete_AnonymousPasteCanBeDeletedByAnyone(t *testing.T) {
+	t.Parallel()
+	svc := &mockPasteService{paste: &api.Paste{ID: 42}}
+	s := New(svc)
+
+	if _, err := s.Delete(context.Background(), &pastev1.DeleteRequest{Id: 42}); err != nil {
+		t.Errorf("expected %v for deleting an anonymous paste, got %v", codes.OK, err)
+	}
+	if !svc.deleted {
+		t.Errorf("expected Delete to be called for an anonymous paste")
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2021 Ilia Frenkel. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE.txt file.
+
+// Package grpc provides a gRPC transport for api.PasteService, it mirrors
+// the routes exposed by src/api/http but speaks protobuf instead of JSON.
+package grpc
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	pastev1 "github.com/iliafrenkel/go-pb/pkg/go/gen/paste/v1"
+	"github.com/iliafrenkel/go-pb/src/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements pastev1.PasteServiceServer by delegating every call to
+// the same api.PasteService used by the HTTP server.
+//
+// Use the `New` function to create an instance of Server.
+type Server struct {
+	pastev1.UnimplementedPasteServiceServer
+	PasteService api.PasteService
+}
+
+// New returns a new Server backed by the provided PasteService.
+func New(svc api.PasteService) *Server {
+	return &Server{PasteService: svc}
+}
+
+// RegisterServer registers srv as the pastev1.PasteService implementation
+// on s, callers should use this instead of calling the generated
+// pastev1.RegisterPasteServiceServer directly.
+func RegisterServer(s grpc.ServiceRegistrar, srv *Server) {
+	pastev1.RegisterPasteServiceServer(s, srv)
+}
+
+// Create implements pastev1.PasteServiceServer.
+func (s *Server) Create(ctx context.Context, req *pastev1.CreateRequest) (*pastev1.Paste, error) {
+	var uid int64
+	if u, ok := UserFromContext(ctx); ok {
+		uid = u.ID
+	}
+
+	expires, err := api.ParseExpiry(req.Expires, time.Now())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to create paste: %v", err)
+	}
+
+	rand.Seed(time.Now().UnixNano())
+	p := &api.Paste{
+		ID:              rand.Int63(),
+		Title:           req.Title,
+		Body:            req.Body,
+		Expires:         expires,
+		Created:         time.Now(),
+		DeleteAfterRead: req.DeleteAfterRead,
+		Syntax:          req.Syntax,
+		UserID:          uid,
+	}
+
+	if err := s.PasteService.Create(p); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to create paste: %v", err)
+	}
+
+	return toProto(p), nil
+}
+
+// Get implements pastev1.PasteServiceServer.
+func (s *Server) Get(ctx context.Context, req *pastev1.GetRequest) (*pastev1.Paste, error) {
+	p, err := s.PasteService.Paste(int64(req.Id))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get paste: %v", err)
+	}
+	if p == nil {
+		return nil, status.Error(codes.NotFound, "paste not found")
+	}
+
+	return toProto(p), nil
+}
+
+// Delete implements pastev1.PasteServiceServer. Like the HTTP DELETE
+// /paste/{id} route, anonymous pastes (UserID == 0) can be deleted by
+// anyone, but a paste that belongs to a user can only be deleted by that
+// same, authenticated user.
+func (s *Server) Delete(ctx context.Context, req *pastev1.DeleteRequest) (*pastev1.DeleteResponse, error) {
+	id := int64(req.Id)
+
+	p, err := s.PasteService.Paste(id)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete paste: %v", err)
+	}
+	if p == nil {
+		return nil, status.Error(codes.NotFound, "paste not found")
+	}
+
+	if p.UserID != 0 {
+		u, ok := UserFromContext(ctx)
+		if !ok || u.ID != p.UserID {
+			return nil, status.Error(codes.PermissionDenied, "not allowed to delete this paste")
+		}
+	}
+
+	if err := s.PasteService.Delete(id); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete paste: %v", err)
+	}
+
+	return &pastev1.DeleteResponse{}, nil
+}
+
+// List implements pastev1.PasteServiceServer. It requires an authenticated
+// user, set on the context by the auth interceptor, and lists only the
+// pastes that belong to them.
+func (s *Server) List(ctx context.Context, req *pastev1.ListRequest) (*pastev1.ListResponse, error) {
+	u, ok := UserFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	pastes := s.PasteService.List(u.ID)
+	resp := &pastev1.ListResponse{Pastes: make([]*pastev1.Paste, 0, len(pastes))}
+	for i := range pastes {
+		resp.Pastes = append(resp.Pastes, toProto(&pastes[i]))
+	}
+
+	return resp, nil
+}
+
+// toProto converts an api.Paste into its protobuf representation. p.ID is
+// never negative in practice (it comes from rand.Int63() or a DB serial),
+// but the proto schema models it as unsigned, so we clamp defensively
+// rather than let a negative ID silently wrap around.
+func toProto(p *api.Paste) *pastev1.Paste {
+	var id uint64
+	if p.ID > 0 {
+		id = uint64(p.ID)
+	}
+
+	return &pastev1.Paste{
+		Id:              id,
+		Title:           p.Title,
+		Body:            p.Body,
+		Expires:         p.Expires.Format(time.RFC3339),
+		Created:         p.Created.Format(time.RFC3339),
+		DeleteAfterRead: p.DeleteAfterRead,
+		Syntax:          p.Syntax,
+		UserId:          p.UserID,
+	}
+}
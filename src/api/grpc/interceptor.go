@@ -0,0 +1,69 @@
+// Copyright 2021 Ilia Frenkel. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE.txt file.
+
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-pkgz/auth/token"
+	"github.com/iliafrenkel/go-pb/src/api"
+	authsvc "github.com/iliafrenkel/go-pb/src/api/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// userContextKey is the context key under which AuthInterceptor stores the
+// authenticated *api.User, use UserFromContext to retrieve it.
+type userContextKey struct{}
+
+// UserFromContext returns the *api.User set by AuthInterceptor, if any.
+func UserFromContext(ctx context.Context) (*api.User, bool) {
+	u, ok := ctx.Value(userContextKey{}).(*api.User)
+	return u, ok
+}
+
+// AuthInterceptor validates the same JWT the HTTP layer issues, expected in
+// the "authorization" metadata key as "Bearer <token>", and, on success,
+// stores the resolved *api.User on the context for handlers to use.
+//
+// Requests without a token are let through unauthenticated, same as the
+// HTTP Trace middleware, handlers that require a user reject them with
+// codes.Unauthenticated.
+func AuthInterceptor(jwtSecret string, usrSvc *authsvc.UserService) grpc.UnaryServerInterceptor {
+	parser := token.NewService(token.Opts{
+		SecretReader: token.SecretFunc(func(string) (string, error) { return jwtSecret, nil }),
+	})
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		vals := md.Get("authorization")
+		if len(vals) == 0 {
+			return handler(ctx, req)
+		}
+
+		tokenStr := strings.TrimPrefix(vals[0], "Bearer ")
+		claims, err := parser.Parse(tokenStr)
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+		}
+		if claims.User == nil {
+			return handler(ctx, req)
+		}
+
+		usr, err := usrSvc.UserStore.Find(api.User{Username: claims.User.Name})
+		if err != nil || usr == nil {
+			return handler(ctx, req)
+		}
+
+		return handler(context.WithValue(ctx, userContextKey{}, usr), req)
+	}
+}
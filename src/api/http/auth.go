@@ -0,0 +1,173 @@
+// Copyright 2021 Ilia Frenkel. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE.txt file.
+
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-pkgz/auth"
+	"github.com/go-pkgz/auth/avatar"
+	"github.com/go-pkgz/auth/provider"
+	"github.com/go-pkgz/auth/token"
+	"github.com/iliafrenkel/go-pb/src/api"
+	authsvc "github.com/iliafrenkel/go-pb/src/api/auth"
+)
+
+// AuthOptions contains all the options needed to wire up the go-pkgz/auth
+// based authentication subsystem: which providers are enabled and the
+// parameters required by each of them.
+type AuthOptions struct {
+	// Secret is used to sign and verify JWT tokens, it is required.
+	Secret string
+	// URL is the root URL of this service, it is used to build the OAuth
+	// callback URLs, ex. "http://127.0.0.1:8080".
+	URL string
+	// Issuer is the value of the "iss" JWT claim, defaults to "go-pb".
+	Issuer string
+	// TokenDuration and CookieDuration control how long the issued JWT and
+	// the cookie that carries it stay valid. Both default to go-pkgz/auth's
+	// own defaults when left at zero.
+	TokenDuration  time.Duration
+	CookieDuration time.Duration
+	// SecureCookies sets the "Secure" flag on the JWT cookie, it should be
+	// true whenever the service is served over HTTPS.
+	SecureCookies bool
+
+	// GithubCID and GithubCSecret enable the GitHub OAuth2 provider.
+	GithubCID     string
+	GithubCSecret string
+	// GoogleCID and GoogleCSecret enable the Google OAuth2 provider.
+	GoogleCID     string
+	GoogleCSecret string
+	// EnableDirect enables the "direct" username/password provider backed
+	// by UserService.
+	EnableDirect bool
+}
+
+// setupAuth creates a go-pkgz/auth Service according to opts, mounts its
+// handlers onto the router under /auth and registers the middleware that
+// populates *api.User on the request context of every subsequent request.
+//
+// usrSvc is used by the "direct" provider to check username/password
+// credentials against our own user store, it may be nil if
+// opts.EnableDirect is false.
+func (h *ApiServer) setupAuth(usrSvc *authsvc.UserService, opts AuthOptions) {
+	svcOpts := auth.Opts{
+		SecretReader:   token.SecretFunc(func(string) (string, error) { return opts.Secret, nil }),
+		TokenDuration:  opts.TokenDuration,
+		CookieDuration: opts.CookieDuration,
+		SecureCookies:  opts.SecureCookies,
+		Issuer:         opts.Issuer,
+		URL:            opts.URL,
+		AvatarStore:    avatar.NewNoOp(),
+	}
+	// A revoked token (see handleLogout) is rejected outright, even though
+	// it otherwise still validates. This is what makes logout take effect
+	// before the token's own expiry. h.KV is read on every call rather than
+	// once here, since it may be set on the ApiServer after New returns.
+	svcOpts.Validator = token.ValidatorFunc(func(tok string, _ token.Claims) bool {
+		if h.KV == nil {
+			return true
+		}
+		_, err := h.KV.Get(revokedKey(tok))
+		return err != nil
+	})
+	authService := auth.NewService(svcOpts)
+
+	if opts.GithubCID != "" {
+		authService.AddProvider("github", opts.GithubCID, opts.GithubCSecret)
+	}
+	if opts.GoogleCID != "" {
+		authService.AddProvider("google", opts.GoogleCID, opts.GoogleCSecret)
+	}
+	if opts.EnableDirect && usrSvc != nil {
+		authService.AddDirectProvider("direct", provider.CredCheckerFunc(
+			func(user, password string) (ok bool, err error) {
+				login := api.UserLogin{Username: user, Password: password}
+				inf, err := usrSvc.Authenticate(login, opts.Secret)
+				if err != nil {
+					return false, nil
+				}
+				return inf.Token != "", nil
+			},
+		))
+	}
+
+	h.Auth = authService
+	h.UserService = usrSvc
+
+	authHandler, avatarHandler := authService.Handlers()
+	h.Router.Any("/auth/*path", h.rateLimiter(writeRateLimit), gin.WrapH(authHandler))
+	h.Router.Any("/avatar/*path", gin.WrapH(avatarHandler))
+	h.Router.POST("/auth/logout", h.rateLimiter(writeRateLimit), h.handleLogout)
+
+	h.Router.Use(h.authMiddleware())
+}
+
+// authMiddleware wraps the go-pkgz/auth Trace middleware (valid token is
+// optional) and, when a valid JWT is found, looks up the corresponding
+// *api.User and stores it in the gin context under the "user" key so that
+// handlers can attribute their actions to the logged in user.
+func (h *ApiServer) authMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if h.Auth == nil {
+			c.Next()
+			return
+		}
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c.Request = r
+			if tknUser, err := token.GetUserInfo(r); err == nil && h.UserService != nil {
+				if usr, err := h.UserService.UserStore.Find(api.User{Username: tknUser.Name}); err == nil && usr != nil {
+					c.Set("user", usr)
+				}
+			}
+			c.Next()
+		})
+		am := h.Auth.Middleware()
+		am.Trace(next).ServeHTTP(c.Writer, c.Request)
+	}
+}
+
+// handleLogout is an HTTP handler for the POST /auth/logout route. It
+// revokes the caller's current JWT by recording it in KV until the token
+// would have expired on its own, so that it's rejected by the Validator set
+// up in setupAuth on its very next use, then clears the JWT cookie.
+func (h *ApiServer) handleLogout(c *gin.Context) {
+	if h.KV == nil {
+		c.String(http.StatusNotImplemented, "session revocation is not configured")
+		return
+	}
+
+	ts := h.Auth.TokenService()
+	claims, tok, err := ts.Get(c.Request)
+	if err != nil {
+		c.String(http.StatusUnauthorized, "not authenticated")
+		return
+	}
+
+	if ttl := time.Until(time.Unix(claims.ExpiresAt, 0)); ttl > 0 {
+		if err := h.KV.Set(revokedKey(tok), "1", ttl); err != nil {
+			log.Printf("failed to revoke session: %v\n", err)
+			c.String(http.StatusInternalServerError, "failed to log out")
+			return
+		}
+	}
+
+	ts.Reset(c.Writer)
+	c.String(http.StatusOK, "logged out")
+}
+
+// revokedKey returns the KV key under which a revoked JWT is recorded. The
+// token is hashed so that the (potentially large, bearer-sensitive) token
+// string itself is never stored in KV.
+func revokedKey(tok string) string {
+	sum := sha256.Sum256([]byte(tok))
+	return "revoked:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/iliafrenkel/go-pb/src/api"
+)
+
+// mockPasteService is a minimal api.PasteService used to exercise the
+// ownership check in handleDelete without a real database.
+type mockPasteService struct {
+	paste   *api.Paste
+	deleted bool
+}
+
+func (m *mockPasteService) Paste(id int64) (*api.Paste, error)    { return m.paste, nil }
+func (m *mockPasteService) Create(p *api.Paste) error             { return nil }
+func (m *mockPasteService) Delete(id int64) error                 { m.deleted = true; return nil }
+func (m *mockPasteService) DeleteAsAdmin(id int64) error          { m.deleted = true; return nil }
+func (m *mockPasteService) List(uid int64) []api.Paste            { return nil }
+func (m *mockPasteService) ListAll(offset, limit int) []api.Paste { return nil }
+
+var _ api.PasteService = (*mockPasteService)(nil)
+
+// newDeleteTestRouter returns a minimal router with a single DELETE
+// /paste/:id route backed by svc, optionally setting usr on the context to
+// simulate an already-authenticated request.
+func newDeleteTestRouter(svc *mockPasteService, usr *api.User) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := &ApiServer{PasteService: svc}
+	r := gin.New()
+	r.DELETE("/paste/:id", func(c *gin.Context) {
+		if usr != nil {
+			c.Set("user", usr)
+		}
+		c.Next()
+	}, h.handleDelete)
+	return r
+}
+
+func Test_HandleDelete_OwnerCanDeleteTheirOwnPaste(t *testing.T) {
+	t.Parallel()
+	owner := &api.User{ID: 1, Username: "owner"}
+	svc := &mockPasteService{paste: &api.Paste{ID: 42, UserID: owner.ID}}
+	r := newDeleteTestRouter(svc, owner)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/paste/a", nil))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected %d for the owner deleting their own paste, got %d", http.StatusOK, w.Code)
+	}
+	if !svc.deleted {
+		t.Errorf("expected Delete to be called for the owner's own paste")
+	}
+}
+
+// Test_HandleDelete_RejectsDeletingAnotherUsersPaste is the "user editing
+// another user's paste" case the backlog asked for: an authenticated user
+// must not be able to delete a paste owned by someone else.
+func Test_HandleDelete_RejectsDeletingAnotherUsersPaste(t *testing.T) {
+	t.Parallel()
+	owner := &api.User{ID: 1, Username: "owner"}
+	attacker := &api.User{ID: 2, Username: "attacker"}
+	svc := &mockPasteService{paste: &api.Paste{ID: 42, UserID: owner.ID}}
+	r := newDeleteTestRouter(svc, attacker)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/paste/a", nil))
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected %d for a user deleting another user's paste, got %d", http.StatusForbidden, w.Code)
+	}
+	if svc.deleted {
+		t.Errorf("Delete must not be called when the caller doesn't own the paste")
+	}
+}
+
+func Test_HandleDelete_AnonymousPasteCanBeDeletedByAnyone(t *testing.T) {
+	t.Parallel()
+	svc := &mockPasteService{paste: &api.Paste{ID: 42}}
+	r := newDeleteTestRouter(svc, nil)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/paste/a", nil))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected %d for deleting an anonymous paste, got %d", http.StatusOK, w.Code)
+	}
+	if !svc.deleted {
+		t.Errorf("expected Delete to be called for an anonymous paste")
+	}
+}
@@ -12,8 +12,13 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	goauth "github.com/go-pkgz/auth"
 	"github.com/iliafrenkel/go-pb/src/api"
+	"github.com/iliafrenkel/go-pb/src/api/auth"
 	"github.com/iliafrenkel/go-pb/src/api/base62"
+	"github.com/iliafrenkel/go-pb/src/api/role"
+	"github.com/iliafrenkel/go-pb/src/mail"
+	"github.com/iliafrenkel/go-pb/src/store/kv"
 )
 
 // ApiServer type provides an HTTP server that calls PasteService methods in
@@ -25,23 +30,72 @@ type ApiServer struct {
 	PasteService api.PasteService
 	Router       *gin.Engine
 	Server       *http.Server
+	// Auth is the go-pkgz/auth service mounted under /auth, it is nil
+	// unless New was given AuthOptions with a non-empty Secret.
+	Auth *goauth.Service
+	// UserService backs the "direct" auth provider and is used to resolve
+	// the authenticated *api.User on every request, see authMiddleware. It
+	// also backs the /auth/verify and /auth/reset-password routes.
+	UserService *auth.UserService
+	// Mailer, if set, is used to notify a paste's owner by email when a
+	// DeleteAfterRead paste they own is burned, see handlePaste.
+	Mailer mail.Mailer
+	// KV, if set, backs server-side session revocation (see handleLogout),
+	// the rate limiter middleware (see rateLimiter in kv.go) and the
+	// per-paste view counter in handlePaste. It is nil-safe: rate limiting
+	// and view counting are skipped when KV is nil, and POST /auth/logout
+	// responds with 501 Not Implemented.
+	KV kv.KVStore
 }
 
 // New function returns an instance of ApiServer using provided PasteService
-// and the default HTTP routes for manipulating pastes.
+// and the default HTTP routes for manipulating pastes. If opts.Secret is
+// not empty the go-pkgz/auth based authentication subsystem is wired up as
+// well, see AuthOptions and setupAuth.
 //
 // The routes are:
-//   GET    /paste/{id} - get paste by ID
-//   POST   /paste      - create new paste
-//   DELETE /paste/{id} - delete paste by ID
-func New(svc api.PasteService) *ApiServer {
+//
+//	GET    /paste/{id} - get paste by ID
+//	POST   /paste      - create new paste
+//	DELETE /paste/{id} - delete paste by ID
+//	GET    /pastes     - list pastes that belong to the current user
+//	GET    /auth/verify         - verify an email address
+//	POST   /auth/reset-password - request or confirm a password reset
+//	POST   /auth/logout         - revoke the current JWT, requires KV
+//
+// POST /paste, DELETE /paste/{id} and every /auth/* route are rate limited
+// when KV is set, see rateLimiter.
+//
+// Routes under /admin require a Moderator role or higher, see RequireRole:
+//
+//	GET    /admin/pastes           - paginated list of every paste
+//	DELETE /admin/paste/{id}       - delete any paste regardless of owner
+//	POST   /admin/users/{id}/role  - change a user's role, Admin role required
+func New(svc api.PasteService, usrSvc *auth.UserService, opts AuthOptions) *ApiServer {
 	var handler ApiServer
 
 	handler.PasteService = svc
+	handler.UserService = usrSvc
 	handler.Router = gin.Default()
+
+	// setupAuth registers the global authMiddleware, it must run before any
+	// route is registered below, gin.Engine.Use only applies to routes
+	// added after the call.
+	if opts.Secret != "" {
+		handler.setupAuth(usrSvc, opts)
+	}
+
 	handler.Router.GET("/paste/:id", handler.handlePaste)
-	handler.Router.POST("/paste", handler.handleCreate)
-	handler.Router.DELETE("/paste/:id", handler.handleDelete)
+	handler.Router.POST("/paste", handler.rateLimiter(writeRateLimit), handler.handleCreate)
+	handler.Router.DELETE("/paste/:id", handler.rateLimiter(writeRateLimit), handler.handleDelete)
+	handler.Router.GET("/pastes", handler.handleList)
+	handler.Router.GET("/auth/verify", handler.rateLimiter(writeRateLimit), handler.handleVerify)
+	handler.Router.POST("/auth/reset-password", handler.rateLimiter(writeRateLimit), handler.handleResetPassword)
+
+	admin := handler.Router.Group("/admin", RequireRole(role.Moderator))
+	admin.GET("/pastes", handler.handleAdminListPastes)
+	admin.DELETE("/paste/:id", handler.handleAdminDeletePaste)
+	admin.POST("/users/:id/role", RequireRole(role.Admin), handler.handleAdminSetRole)
 
 	return &handler
 }
@@ -64,17 +118,25 @@ func (h *ApiServer) ListenAndServe(addr string) error {
 	return h.Server.ListenAndServe()
 }
 
+// pasteResponse is what GET /paste/{id} returns: the paste itself plus its
+// view count, when KV is configured to track one.
+type pasteResponse struct {
+	*api.Paste
+	Views int64 `json:"views,omitempty"`
+}
+
 // handlePaste is an HTTP handler for the GET /paste/{id} route, it returns
 // the paste as a JSON string or 404 Not Found.
 func (h *ApiServer) handlePaste(c *gin.Context) {
 	// We expect the id parameter as base62 encoded string, we try to decode
 	// it into a uint64 paste id and return 404 if we can't.
-	id, err := base62.Decode(c.Param("id"))
+	uid, err := base62.Decode(c.Param("id"))
 	if err != nil {
 		log.Println(err)
 		c.String(http.StatusNotFound, "paste not found")
 		return
 	}
+	id := int64(uid)
 
 	p, err := h.PasteService.Paste(id)
 	if err != nil {
@@ -83,11 +145,80 @@ func (h *ApiServer) handlePaste(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, p)
+	if p == nil {
+		c.String(http.StatusNotFound, "paste not found")
+		return
+	}
+
+	// Pastes aren't swept out by a background job, we check expiry lazily
+	// on the next read instead, same as the DeleteAfterRead check below.
+	if !p.Expires.IsZero() && time.Now().After(p.Expires) {
+		h.PasteService.Delete(p.ID)
+		h.notifyExpired(p)
+		c.String(http.StatusNotFound, "paste not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, pasteResponse{Paste: p, Views: h.countView(id)})
 
 	// We "burn" the paste if DeleteAfterRead flag is set.
 	if p.DeleteAfterRead {
 		h.PasteService.Delete(p.ID)
+		h.notifyBurn(p)
+	}
+}
+
+// notifyBurn emails the owner of p, if any, to let them know their
+// DeleteAfterRead paste has just been read and removed. It is a no-op if
+// Mailer or UserService aren't configured, or if the paste is anonymous.
+func (h *ApiServer) notifyBurn(p *api.Paste) {
+	if h.Mailer == nil || h.UserService == nil || p.UserID == 0 {
+		return
+	}
+
+	usr, err := h.UserService.UserStore.Find(api.User{ID: p.UserID})
+	if err != nil || usr == nil {
+		return
+	}
+
+	data := struct {
+		Username string
+		Title    string
+		PasteURL string
+	}{
+		Username: usr.Username,
+		Title:    p.Title,
+		PasteURL: p.URL(),
+	}
+	if err := h.Mailer.SendTemplate("mail-burn-notify.go.txt", usr.Email, data); err != nil {
+		log.Printf("failed to send burn notification email: %v\n", err)
+	}
+}
+
+// notifyExpired emails the owner of p, if any, to let them know their
+// paste has expired and was just removed. It is a no-op if Mailer or
+// UserService aren't configured, or if the paste is anonymous.
+func (h *ApiServer) notifyExpired(p *api.Paste) {
+	if h.Mailer == nil || h.UserService == nil || p.UserID == 0 {
+		return
+	}
+
+	usr, err := h.UserService.UserStore.Find(api.User{ID: p.UserID})
+	if err != nil || usr == nil {
+		return
+	}
+
+	data := struct {
+		Username string
+		Title    string
+		PasteURL string
+	}{
+		Username: usr.Username,
+		Title:    p.Title,
+		PasteURL: p.URL(),
+	}
+	if err := h.Mailer.SendTemplate("mail-paste-expired.go.txt", usr.Email, data); err != nil {
+		log.Printf("failed to send expiry notification email: %v\n", err)
 	}
 }
 
@@ -198,16 +329,26 @@ func (h *ApiServer) handleCreate(c *gin.Context) {
 		return
 	}
 
-	// Create new paste
+	// Create new paste, attributing it to the authenticated user, if any.
+	// Anonymous requests (no user on the context) keep creating anonymous
+	// pastes, same as before.
+	var uid int64
+	if u, ok := c.Get("user"); ok {
+		if usr, ok := u.(*api.User); ok {
+			uid = usr.ID
+		}
+	}
+
 	rand.Seed(time.Now().UnixNano())
 	p := api.Paste{
-		ID:              rand.Uint64(),
+		ID:              rand.Int63(),
 		Title:           data.Title,
 		Body:            data.Body,
 		Expires:         time.Time{},
 		Created:         time.Now(),
 		DeleteAfterRead: data.DeleteAfterRead,
 		Syntax:          data.Syntax,
+		UserID:          uid,
 	}
 	if err := h.PasteService.Create(&p); err != nil {
 		log.Printf("failed to create paste: %v\n", err)
@@ -219,16 +360,52 @@ func (h *ApiServer) handleCreate(c *gin.Context) {
 }
 
 // handleDelete is an HTTP handler for the DELETE /paste/{id} route. Deletes
-// the paste by id and returns 200 OK or 404 Not Found.
+// the paste by id and returns 200 OK, 404 Not Found or 403 Forbidden if the
+// paste belongs to someone else. Use DELETE /admin/paste/{id} (see
+// handleAdminDeletePaste) to bypass ownership.
 func (h *ApiServer) handleDelete(c *gin.Context) {
-	id, err := base62.Decode(c.Param("id"))
+	uid, err := base62.Decode(c.Param("id"))
 	if err != nil {
 		c.String(http.StatusNotFound, "paste not found")
 		return
 	}
+	id := int64(uid)
+
+	p, err := h.PasteService.Paste(id)
+	if err != nil || p == nil {
+		c.String(http.StatusNotFound, "paste not found")
+		return
+	}
+
+	if p.UserID != 0 {
+		u, _ := c.Get("user")
+		usr, ok := u.(*api.User)
+		if !ok || usr.ID != p.UserID {
+			c.String(http.StatusForbidden, "not allowed to delete this paste")
+			return
+		}
+	}
 
 	if err := h.PasteService.Delete(id); err != nil {
 		c.String(http.StatusNotFound, "paste not found")
 		return
 	}
-}
\ No newline at end of file
+}
+
+// handleList is an HTTP handler for the GET /pastes route. It requires an
+// authenticated user and returns the JSON array of pastes that belong to
+// them, or 401 Unauthorized if there is no user on the request context.
+func (h *ApiServer) handleList(c *gin.Context) {
+	u, ok := c.Get("user")
+	if !ok {
+		c.String(http.StatusUnauthorized, "authentication required")
+		return
+	}
+	usr, ok := u.(*api.User)
+	if !ok {
+		c.String(http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.PasteService.List(usr.ID))
+}
@@ -0,0 +1,130 @@
+// Copyright 2021 Ilia Frenkel. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE.txt file.
+
+package http
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/iliafrenkel/go-pb/src/api"
+)
+
+// rateLimit describes a sliding-window request quota enforced by
+// rateLimiter.
+type rateLimit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// writeRateLimit caps the routes that mutate state or touch the auth
+// subsystem, it applies to POST /paste, DELETE /paste/{id} and every
+// /auth/* route.
+//
+// TODO: Make the limit configurable.
+var writeRateLimit = rateLimit{Requests: 30, Window: time.Minute}
+
+// rateLimiter returns a gin middleware enforcing limit using a sliding
+// window counter kept in KV: each fixed-size window gets its own counter,
+// and the request count for the trailing window ending now is estimated
+// by weighting the previous window's counter by the fraction of it still
+// inside the trailing window, see slidingWindowCount. This keeps a client
+// from bursting up to 2x its quota around a window boundary, which is
+// what a plain fixed-window counter would allow. It is a no-op when KV is
+// nil. On every response it sets the X-RateLimit-Limit and
+// X-RateLimit-Remaining headers, and responds with 429 Too Many Requests
+// once the quota is used up.
+func (h *ApiServer) rateLimiter(limit rateLimit) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if h.KV == nil {
+			c.Next()
+			return
+		}
+
+		now := time.Now()
+		windowSize := int64(limit.Window.Seconds())
+		windowIdx := now.Unix() / windowSize
+		base := "ratelimit:" + rateLimitKey(c) + ":"
+		currKey := base + strconv.FormatInt(windowIdx, 10)
+		prevKey := base + strconv.FormatInt(windowIdx-1, 10)
+
+		curr, err := h.KV.Incr(currKey)
+		if err != nil {
+			log.Printf("rate limiter: failed to increment %s: %v\n", currKey, err)
+			c.Next()
+			return
+		}
+		if curr == 1 {
+			// Kept around for 2x the window so it's still readable as the
+			// "previous" window's counter once the next one starts.
+			if err := h.KV.Expire(currKey, 2*limit.Window); err != nil {
+				log.Printf("rate limiter: failed to set expiry on %s: %v\n", currKey, err)
+			}
+		}
+
+		var prev int64
+		if v, err := h.KV.Get(prevKey); err == nil {
+			prev, _ = strconv.ParseInt(v, 10, 64)
+		}
+
+		elapsed := time.Duration(now.Unix()%windowSize) * time.Second
+		count := slidingWindowCount(prev, curr, elapsed, limit.Window)
+
+		remaining := int64(limit.Requests) - count
+		if remaining < 0 {
+			remaining = 0
+		}
+		c.Header("X-RateLimit-Limit", strconv.Itoa(limit.Requests))
+		c.Header("X-RateLimit-Remaining", strconv.FormatInt(remaining, 10))
+
+		if count > int64(limit.Requests) {
+			c.Header("Retry-After", strconv.Itoa(int(limit.Window.Seconds())))
+			c.AbortWithStatus(http.StatusTooManyRequests)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// slidingWindowCount estimates the number of requests in the trailing
+// window of length window that ends elapsed into the current fixed
+// window, by weighting the previous fixed window's counter (prev) by the
+// fraction of it still inside the trailing window and adding the current
+// fixed window's counter (curr) in full.
+func slidingWindowCount(prev, curr int64, elapsed, window time.Duration) int64 {
+	weight := float64(window-elapsed) / float64(window)
+	return curr + int64(float64(prev)*weight)
+}
+
+// rateLimitKey identifies the caller for rate limiting purposes: the
+// authenticated user's ID when there is one, the client's IP otherwise.
+func rateLimitKey(c *gin.Context) string {
+	if u, ok := c.Get("user"); ok {
+		if usr, ok := u.(*api.User); ok {
+			return "user:" + strconv.FormatInt(usr.ID, 10)
+		}
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// countView atomically increments and returns the view counter for paste
+// id, or 0 if KV isn't configured.
+func (h *ApiServer) countView(id int64) int64 {
+	if h.KV == nil {
+		return 0
+	}
+
+	n, err := h.KV.Incr(fmt.Sprintf("views:%d", id))
+	if err != nil {
+		log.Printf("failed to increment view counter for paste %d: %v\n", id, err)
+		return 0
+	}
+
+	return n
+}
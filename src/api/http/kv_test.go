@@ -0,0 +1,110 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/iliafrenkel/go-pb/src/store/kv"
+)
+
+// newRateLimitTestRouter returns a minimal router with a single GET /ping
+// route gated by a rateLimiter allowing at most limit.Requests calls per
+// limit.Window, backed by store.
+func newRateLimitTestRouter(store kv.KVStore, limit rateLimit) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := &ApiServer{KV: store}
+	r := gin.New()
+	r.GET("/ping", h.rateLimiter(limit), func(c *gin.Context) {
+		c.String(http.StatusOK, "pong")
+	})
+	return r
+}
+
+func Test_RateLimiter_AllowsWithinLimit(t *testing.T) {
+	t.Parallel()
+	r := newRateLimitTestRouter(kv.NewMemory(), rateLimit{Requests: 2, Window: time.Minute})
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d: expected %d, got %d", i+1, http.StatusOK, w.Code)
+		}
+	}
+}
+
+func Test_RateLimiter_RejectsOverLimit(t *testing.T) {
+	t.Parallel()
+	r := newRateLimitTestRouter(kv.NewMemory(), rateLimit{Requests: 2, Window: time.Minute})
+
+	var last int
+	for i := 0; i < 3; i++ {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+		last = w.Code
+	}
+
+	if last != http.StatusTooManyRequests {
+		t.Errorf("expected %d on the 3rd request over a limit of 2, got %d", http.StatusTooManyRequests, last)
+	}
+}
+
+func Test_RateLimiter_NilKVIsNoop(t *testing.T) {
+	t.Parallel()
+	r := newRateLimitTestRouter(nil, rateLimit{Requests: 1, Window: time.Minute})
+
+	for i := 0; i < 5; i++ {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d: rate limiter should be a no-op without KV, got %d", i+1, w.Code)
+		}
+	}
+}
+
+func Test_CountView_IncrementsPerCall(t *testing.T) {
+	t.Parallel()
+	h := &ApiServer{KV: kv.NewMemory()}
+
+	var last int64
+	for i := int64(1); i <= 3; i++ {
+		last = h.countView(42)
+		if last != i {
+			t.Errorf("countView() = %d, want %d", last, i)
+		}
+	}
+}
+
+func Test_CountView_NilKVReturnsZero(t *testing.T) {
+	t.Parallel()
+	h := &ApiServer{}
+
+	if v := h.countView(42); v != 0 {
+		t.Errorf("countView() with no KV configured = %d, want 0", v)
+	}
+}
+
+func Test_SlidingWindowCount(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name            string
+		prev, curr      int64
+		elapsed, window time.Duration
+		want            int64
+	}{
+		{"start of window counts the full previous window", 10, 0, 0, time.Minute, 10},
+		{"end of window barely counts the previous window", 10, 0, 59 * time.Second, time.Minute, 0},
+		{"halfway through the window halves the previous count", 10, 0, 30 * time.Second, time.Minute, 5},
+		{"current window's count is always added in full", 10, 4, 30 * time.Second, time.Minute, 9},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := slidingWindowCount(c.prev, c.curr, c.elapsed, c.window); got != c.want {
+				t.Errorf("slidingWindowCount(%d, %d, %s, %s) = %d, want %d", c.prev, c.curr, c.elapsed, c.window, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2021 Ilia Frenkel. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE.txt file.
+
+package http
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// verifyRequest is the expected query for the GET /auth/verify route.
+type verifyRequest struct {
+	Token string `form:"token" binding:"required"`
+}
+
+// handleVerify is an HTTP handler for the GET /auth/verify route, it marks
+// the user that owns the token as verified.
+func (h *ApiServer) handleVerify(c *gin.Context) {
+	if h.UserService == nil {
+		c.String(http.StatusNotImplemented, "email verification is not configured")
+		return
+	}
+
+	var req verifyRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.String(http.StatusBadRequest, "missing or invalid token")
+		return
+	}
+
+	if err := h.UserService.Verify(req.Token); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.String(http.StatusOK, "email verified")
+}
+
+// resetPasswordRequest is the expected body for the POST /auth/reset-password
+// route. Only Email is required to start a reset, Token and Password are
+// required to complete one.
+type resetPasswordRequest struct {
+	Email    string `json:"email"`
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// handleResetPassword is an HTTP handler for the POST /auth/reset-password
+// route. Sending just an email starts a password reset by emailing a reset
+// link, sending a token and a new password completes it.
+func (h *ApiServer) handleResetPassword(c *gin.Context) {
+	if h.UserService == nil {
+		c.String(http.StatusNotImplemented, "password reset is not configured")
+		return
+	}
+
+	var req resetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.String(http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.Token != "" {
+		if err := h.UserService.ConfirmPasswordReset(req.Token, req.Password); err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		c.String(http.StatusOK, "password has been reset")
+		return
+	}
+
+	// RequestPasswordReset only ever errors on an internal failure (mailer
+	// not configured, database error, SMTP failure) - an unknown email is
+	// not an error, so any err here is ours to fix, not the caller's, and
+	// must not leak internal details (ex. SMTP server responses) to them.
+	if err := h.UserService.RequestPasswordReset(req.Email); err != nil {
+		log.Printf("failed to request a password reset: %v\n", err)
+		c.String(http.StatusInternalServerError, "failed to send the reset email, please try again later")
+		return
+	}
+	c.String(http.StatusOK, "if the email address is registered, a reset link has been sent")
+}
@@ -0,0 +1,113 @@
+// Copyright 2021 Ilia Frenkel. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE.txt file.
+
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/iliafrenkel/go-pb/src/api"
+	"github.com/iliafrenkel/go-pb/src/api/base62"
+	"github.com/iliafrenkel/go-pb/src/api/role"
+)
+
+// RequireRole returns a gin middleware that only lets requests through
+// when the authenticated user's role is at least min. It relies on
+// authMiddleware having already populated *api.User on the context, so the
+// role it checks is always the one currently on file, never whatever a
+// client's JWT happens to claim.
+func RequireRole(min role.UserRole) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		u, ok := c.Get("user")
+		if !ok {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		usr, ok := u.(*api.User)
+		if !ok || !usr.Role.Allows(min) {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		c.Next()
+	}
+}
+
+// adminListPastesRequest is the expected query for GET /admin/pastes.
+type adminListPastesRequest struct {
+	Offset int `form:"offset"`
+	Limit  int `form:"limit"`
+}
+
+// handleAdminListPastes is an HTTP handler for the GET /admin/pastes
+// route, it returns a paginated, global list of pastes regardless of
+// owner.
+func (h *ApiServer) handleAdminListPastes(c *gin.Context) {
+	var req adminListPastesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.String(http.StatusBadRequest, "invalid offset or limit")
+		return
+	}
+	if req.Limit <= 0 {
+		req.Limit = 50
+	}
+
+	c.JSON(http.StatusOK, h.PasteService.ListAll(req.Offset, req.Limit))
+}
+
+// handleAdminDeletePaste is an HTTP handler for the DELETE
+// /admin/paste/:id route, it deletes any paste regardless of owner.
+func (h *ApiServer) handleAdminDeletePaste(c *gin.Context) {
+	uid, err := base62.Decode(c.Param("id"))
+	if err != nil {
+		c.String(http.StatusNotFound, "paste not found")
+		return
+	}
+	id := int64(uid)
+
+	if err := h.PasteService.DeleteAsAdmin(id); err != nil {
+		c.String(http.StatusNotFound, "paste not found")
+		return
+	}
+}
+
+// adminSetRoleRequest is the expected body for POST /admin/users/:id/role.
+type adminSetRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// handleAdminSetRole is an HTTP handler for the POST /admin/users/:id/role
+// route, it promotes or demotes a user to the given role.
+func (h *ApiServer) handleAdminSetRole(c *gin.Context) {
+	if h.UserService == nil {
+		c.String(http.StatusNotImplemented, "user management is not configured")
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.String(http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	var req adminSetRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.String(http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	r, err := role.Parse(req.Role)
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.UserService.SetRole(id, r); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.String(http.StatusOK, "role updated")
+}
@@ -0,0 +1,164 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	goauth "github.com/go-pkgz/auth"
+	"github.com/go-pkgz/auth/avatar"
+	"github.com/go-pkgz/auth/token"
+	"github.com/golang-jwt/jwt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/iliafrenkel/go-pb/src/api"
+	authsvc "github.com/iliafrenkel/go-pb/src/api/auth"
+	"github.com/iliafrenkel/go-pb/src/api/role"
+)
+
+// newRoleTestRouter returns a minimal router with a single /admin route
+// gated by RequireRole(min), optionally setting usr on the context to
+// simulate an already-authenticated request.
+func newRoleTestRouter(min role.UserRole, usr *api.User) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/admin", func(c *gin.Context) {
+		if usr != nil {
+			c.Set("user", usr)
+		}
+		c.Next()
+	}, RequireRole(min), func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+	return r
+}
+
+func Test_RequireRole_GuestIsRejected(t *testing.T) {
+	t.Parallel()
+	r := newRoleTestRouter(role.Moderator, nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected %d for an unauthenticated request, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
+
+func Test_RequireRole_InsufficientRoleIsRejected(t *testing.T) {
+	t.Parallel()
+	usr := &api.User{ID: 1, Username: "regular", Role: role.User}
+	r := newRoleTestRouter(role.Moderator, usr)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected %d for a user hitting a moderator-only route, got %d", http.StatusForbidden, w.Code)
+	}
+}
+
+func Test_RequireRole_SufficientRoleIsAllowed(t *testing.T) {
+	t.Parallel()
+	usr := &api.User{ID: 1, Username: "mod", Role: role.Moderator}
+	r := newRoleTestRouter(role.Moderator, usr)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected %d for a moderator hitting a moderator-only route, got %d", http.StatusOK, w.Code)
+	}
+}
+
+func Test_RequireRole_ModeratorCannotReachAdminOnlyRoute(t *testing.T) {
+	t.Parallel()
+	usr := &api.User{ID: 1, Username: "mod", Role: role.Moderator}
+	r := newRoleTestRouter(role.Admin, usr)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected %d for a moderator hitting an admin-only route, got %d", http.StatusForbidden, w.Code)
+	}
+}
+
+// roleTestStore is a minimal in-memory auth.UserStore backing the tests
+// below, it only ever holds the one user set on it.
+type roleTestStore struct {
+	mu  sync.Mutex
+	usr *api.User
+}
+
+func (s *roleTestStore) Store(usr api.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.usr = &usr
+	return nil
+}
+func (s *roleTestStore) Find(usr api.User) (*api.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.usr != nil && usr.Username != "" && s.usr.Username == usr.Username {
+		return s.usr, nil
+	}
+	return nil, nil
+}
+
+// Test_RequireRole_IgnoresClientSuppliedRole forges a JWT carrying a
+// "role": "admin" claim for a user who is actually stored as a plain
+// "user", sends it through the real authMiddleware/RequireRole chain (not
+// the c.Set("user", ...) shortcut the other tests in this file use) and
+// checks that the tampered claim never reaches RequireRole: authMiddleware
+// re-reads the role fresh from the UserStore on every request, see the
+// comment on auth.claims for why the token's own role is decorative.
+func Test_RequireRole_IgnoresClientSuppliedRole(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	store := &roleTestStore{}
+	if err := store.Store(api.User{ID: 1, Username: "attacker", Role: role.User}); err != nil {
+		t.Fatalf("failed to seed the store: %v", err)
+	}
+
+	authService := goauth.NewService(goauth.Opts{
+		SecretReader:  token.SecretFunc(func(string) (string, error) { return "test-secret", nil }),
+		Issuer:        "go-pb-test",
+		URL:           "http://127.0.0.1",
+		AvatarStore:   avatar.NewNoOp(),
+		SendJWTHeader: true,
+	})
+
+	tokenString, err := authService.TokenService().Token(token.Claims{
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+		},
+		User: &token.User{Name: "attacker", Role: "admin"},
+	})
+	if err != nil {
+		t.Fatalf("failed to forge a token: %v", err)
+	}
+
+	h := &ApiServer{Auth: authService, UserService: &authsvc.UserService{UserStore: store}}
+	r := gin.New()
+	r.Use(h.authMiddleware())
+	r.GET("/admin", RequireRole(role.Admin), func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+	req.Header.Set("X-JWT", tokenString)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected %d, a client can't escalate privileges by editing a JWT claim, got %d", http.StatusForbidden, w.Code)
+	}
+}
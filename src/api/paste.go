@@ -0,0 +1,103 @@
+// Copyright 2021 Ilia Frenkel. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE.txt file.
+
+// Package api defines the domain types shared by every transport (see
+// src/api/http and src/api/grpc) and storage backend (see
+// src/api/paste/sqldb and src/api/auth/sqldb): Paste, User and the
+// PasteService interface they are both written against.
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/iliafrenkel/go-pb/src/api/base62"
+)
+
+// Paste represents a single paste: its content plus the metadata needed to
+// serve, expire and, optionally, attribute it to a user.
+type Paste struct {
+	ID              int64     `json:"id" gorm:"primaryKey"`
+	Title           string    `json:"title"`
+	Body            string    `json:"body" binding:"required"`
+	Expires         time.Time `json:"expires,omitempty"`
+	DeleteAfterRead bool      `json:"delete_after_read,omitempty"`
+	Privacy         string    `json:"privacy,omitempty"`
+	// Password, if set, must already be hashed by the caller, PasteService
+	// implementations store it as-is.
+	Password string    `json:"-"`
+	Syntax   string    `json:"syntax,omitempty"`
+	Created  time.Time `json:"created"`
+	UserID   int64     `json:"user_id,omitempty"`
+	User     *User     `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// URL returns the path at which this paste is reachable, ex. "/paste/3f"
+// for ID 123, the ID is encoded with base62.Encode.
+func (p *Paste) URL() string {
+	return "/paste/" + base62.Encode(uint64(p.ID))
+}
+
+// PasteService is implemented by any storage backend capable of persisting
+// and retrieving pastes, see sqldb for a database backed implementation.
+// Every transport (src/api/http, src/api/grpc) is written against this
+// interface, never against a concrete implementation.
+type PasteService interface {
+	// Paste returns the paste with the given ID, or a nil paste and a nil
+	// error if it doesn't exist.
+	Paste(id int64) (*Paste, error)
+	// Create persists p, which must already be fully populated (ID,
+	// Created, ...) by the caller.
+	Create(p *Paste) error
+	// Delete removes the paste with the given ID.
+	Delete(id int64) error
+	// List returns every paste owned by uid, or every anonymous paste when
+	// uid is 0.
+	List(uid int64) []Paste
+	// ListAll returns a page of pastes across all users, regardless of
+	// owner, ordered by ID. It is meant to only be reachable through a
+	// role-checked path, ex. the GET /admin/pastes route.
+	ListAll(offset, limit int) []Paste
+	// DeleteAsAdmin removes the paste with the given ID regardless of its
+	// owner. Unlike Delete it is meant to only be reachable through a
+	// role-checked path, ex. the DELETE /admin/paste/:id route.
+	DeleteAsAdmin(id int64) error
+}
+
+// ParseExpiry turns a duration spec of the form "nx", where n is a number
+// and x is a unit character (m: minutes, h: hours, d: days, w: weeks,
+// M: months, y: years), into an absolute time relative to from. The
+// special value "never" (or an empty string) means no expiration and
+// returns the zero time.
+func ParseExpiry(spec string, from time.Time) (time.Time, error) {
+	if spec == "" || spec == "never" {
+		return time.Time{}, nil
+	}
+	if len(spec) < 2 {
+		return time.Time{}, fmt.Errorf("wrong duration format: %s", spec)
+	}
+
+	dur, err := strconv.Atoi(spec[:len(spec)-1])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("wrong duration format: %s, error: %w", spec, err)
+	}
+
+	switch spec[len(spec)-1] {
+	case 'm': // minutes
+		return from.Add(time.Duration(dur) * time.Minute), nil
+	case 'h': // hours
+		return from.Add(time.Duration(dur) * time.Hour), nil
+	case 'd': // days
+		return from.AddDate(0, 0, dur), nil
+	case 'w': // weeks
+		return from.AddDate(0, 0, dur*7), nil
+	case 'M': // months
+		return from.AddDate(0, dur, 0), nil
+	case 'y': // years
+		return from.AddDate(dur, 0, 0), nil
+	default:
+		return time.Time{}, fmt.Errorf("unknown duration format: %s", spec)
+	}
+}
@@ -9,12 +9,8 @@ package sqldb
 import (
 	"errors"
 	"fmt"
-	"math/rand"
-	"strconv"
-	"time"
 
 	"github.com/iliafrenkel/go-pb/src/api"
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
@@ -41,7 +37,6 @@ func New(opts SvcOptions) (*PasteService, error) {
 	var s PasteService
 	s.Options = opts
 	db := opts.DBConnection
-	rand.Seed(time.Now().UnixNano())
 
 	if s.Options.DBAutoMigrate {
 		db.AutoMigrate(&api.Paste{})
@@ -51,14 +46,14 @@ func New(opts SvcOptions) (*PasteService, error) {
 	return &s, nil
 }
 
-// Get returns a paste by it's ID.
+// Paste returns a paste by it's ID.
 // The return values are as follows:
 // - if there is a problem talking to the database paste== nil, err != nil
 // - if paste is not found paste== nil, err == nil
 // - if paste is found paste != nil, err == nil
-func (s *PasteService) Get(id int64) (*api.Paste, error) {
+func (s *PasteService) Paste(id int64) (*api.Paste, error) {
 	if s.db == nil {
-		return nil, errors.New("Get: no database connection")
+		return nil, errors.New("Paste: no database connection")
 	}
 	var paste api.Paste
 	err := s.db.Joins("User").First(&paste, id).Error
@@ -66,76 +61,26 @@ func (s *PasteService) Get(id int64) (*api.Paste, error) {
 		return nil, nil
 	}
 	if err != nil {
-		return nil, fmt.Errorf("Get: database error: %w", err)
+		return nil, fmt.Errorf("Paste: database error: %w", err)
 	}
 
 	return &paste, nil
 }
 
-// Create initialises a new paste from the provided data and adds it to the
-// storage. It returns the newly created paste.
-func (s *PasteService) Create(p api.PasteForm) (*api.Paste, error) {
-	var (
-		expires, created time.Time
-	)
-	created = time.Now()
-	expires = time.Time{} // zero time means no expiration, this is the default
-	// We expect the expiration to be in the form of "nx" where "n" is a number
-	// and "x" is a time unit character: m for minute, h for hour, d for day,
-	// w for week, M for month and y for year.
-	if p.Expires != "never" && len(p.Expires) > 1 {
-		dur, err := strconv.Atoi(p.Expires[:len(p.Expires)-1])
-		if err != nil {
-			return nil, fmt.Errorf("wrong duration format: %s, error: %w", p.Expires, err)
-		}
-		switch p.Expires[len(p.Expires)-1] {
-		case 'm': //minutes
-			expires = created.Add(time.Duration(dur) * time.Minute)
-		case 'h': //hours
-			expires = created.Add(time.Duration(dur) * time.Hour)
-		case 'd': //days
-			expires = created.AddDate(0, 0, dur)
-		case 'w': //weeks
-			expires = created.AddDate(0, 0, dur*7)
-		case 'M': //months
-			expires = created.AddDate(0, dur, 0)
-		case 'y': //days
-			expires = created.AddDate(dur, 0, 0)
-		default:
-			return nil, fmt.Errorf("unknown duration format: %s", p.Expires)
-		}
-	}
-	// Create new paste with a randomly generated ID and a hashed password.
-	if p.Password != "" {
-		hash, err := bcrypt.GenerateFromPassword([]byte(p.Password), bcrypt.DefaultCost)
-		if err != nil {
-			return nil, err
-		}
-		p.Password = string(hash)
-	}
-	newPaste := api.Paste{
-		ID:              rand.Int63(),
-		Title:           p.Title,
-		Body:            p.Body,
-		Expires:         expires,
-		DeleteAfterRead: p.DeleteAfterRead,
-		Privacy:         p.Privacy,
-		Password:        p.Password,
-		Created:         created,
-		Syntax:          p.Syntax,
-		UserID:          p.UserID,
-	}
+// Create adds p, which the caller must have already fully populated (ID,
+// Created, ...), to the storage.
+func (s *PasteService) Create(p *api.Paste) error {
 	var err error
 	if p.UserID == 0 {
-		err = s.db.Omit("user_id").Create(&newPaste).Error
+		err = s.db.Omit("user_id").Create(p).Error
 	} else {
-		err = s.db.Create(&newPaste).Error
+		err = s.db.Create(p).Error
 	}
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("Create: database error: %w", err)
 	}
 
-	return &newPaste, nil
+	return nil
 }
 
 // Delete removes the paste from the storage
@@ -143,6 +88,13 @@ func (s *PasteService) Delete(id int64) error {
 	return s.db.Delete(&api.Paste{}, id).Error
 }
 
+// DeleteAsAdmin removes the paste from the storage regardless of its
+// owner. Unlike Delete it is meant to only be reachable through a
+// role-checked path, ex. the DELETE /admin/paste/:id route.
+func (s *PasteService) DeleteAsAdmin(id int64) error {
+	return s.db.Delete(&api.Paste{}, id).Error
+}
+
 // List returns a slice of all the pastes by a user ID.
 func (s *PasteService) List(uid int64) []api.Paste {
 	var pastes []api.Paste
@@ -155,3 +107,14 @@ func (s *PasteService) List(uid int64) []api.Paste {
 
 	return pastes
 }
+
+// ListAll returns a page of pastes across all users, regardless of owner,
+// ordered by ID. It is meant to only be reachable through a role-checked
+// path, ex. the GET /admin/pastes route.
+func (s *PasteService) ListAll(offset, limit int) []api.Paste {
+	var pastes []api.Paste
+
+	s.db.Order("id").Offset(offset).Limit(limit).Find(&pastes)
+
+	return pastes
+}
@@ -0,0 +1,50 @@
+// Copyright 2021 Ilia Frenkel. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE.txt file.
+
+// Package role defines the ordered set of user roles used to gate access
+// to pastes and admin-only endpoints.
+package role
+
+import "fmt"
+
+// UserRole is the access level of a user. Roles are ordered from the least
+// to the most privileged so that `usr.Role >= min` is enough to check
+// whether a user satisfies a minimum role requirement.
+type UserRole int
+
+// The ordered set of roles known to go-pb.
+const (
+	Guest UserRole = iota
+	User
+	Moderator
+	Admin
+)
+
+// names holds the string representation of every role, in UserRole order.
+var names = [...]string{"guest", "user", "moderator", "admin"}
+
+// String returns the role's name, ex. "moderator".
+func (r UserRole) String() string {
+	if r < Guest || r > Admin {
+		return fmt.Sprintf("role(%d)", int(r))
+	}
+	return names[r]
+}
+
+// Allows reports whether r satisfies a requirement of at least min.
+func (r UserRole) Allows(min UserRole) bool {
+	return r >= min
+}
+
+// Parse returns the UserRole with the given name, it is the inverse of
+// String. It is used, for example, to parse the role from the
+// POST /admin/users/:id/role request body.
+func Parse(name string) (UserRole, error) {
+	for i, n := range names {
+		if n == name {
+			return UserRole(i), nil
+		}
+	}
+	return Guest, fmt.Errorf("unknown role: %q", name)
+}
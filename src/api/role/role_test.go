@@ -0,0 +1,46 @@
+package role
+
+import "testing"
+
+func Test_UserRoleOrdering(t *testing.T) {
+	t.Parallel()
+	if !Admin.Allows(Moderator) || !Admin.Allows(User) || !Admin.Allows(Guest) {
+		t.Errorf("Admin should satisfy every lower role requirement")
+	}
+	if Guest.Allows(User) {
+		t.Errorf("Guest should not satisfy the User role requirement")
+	}
+	if Moderator.Allows(Admin) {
+		t.Errorf("Moderator should not satisfy the Admin role requirement")
+	}
+}
+
+func Test_UserRoleString(t *testing.T) {
+	t.Parallel()
+	cases := map[UserRole]string{
+		Guest:     "guest",
+		User:      "user",
+		Moderator: "moderator",
+		Admin:     "admin",
+	}
+	for r, want := range cases {
+		if got := r.String(); got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+	}
+}
+
+func Test_Parse(t *testing.T) {
+	t.Parallel()
+	r, err := Parse("moderator")
+	if err != nil {
+		t.Fatalf("Failed to parse a known role: %v", err)
+	}
+	if r != Moderator {
+		t.Errorf("Parse(\"moderator\") = %v, want %v", r, Moderator)
+	}
+
+	if _, err := Parse("superuser"); err == nil {
+		t.Errorf("Succeeded to parse an unknown role")
+	}
+}
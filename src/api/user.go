@@ -0,0 +1,44 @@
+// Copyright 2021 Ilia Frenkel. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE.txt file.
+
+package api
+
+import "github.com/iliafrenkel/go-pb/src/api/role"
+
+// User represents a registered user. It is persisted through
+// auth.UserStore, see src/api/auth/sqldb for a database backed
+// implementation.
+type User struct {
+	ID       int64         `json:"id" gorm:"primaryKey"`
+	Username string        `json:"username" gorm:"uniqueIndex"`
+	Email    string        `json:"-" gorm:"uniqueIndex"`
+	Password string        `json:"-"`
+	Role     role.UserRole `json:"role"`
+	// Verified is false until the user clicks the link sent by
+	// auth.UserService.Create, see VerifyToken. It is always true when no
+	// Mailer is configured.
+	Verified bool `json:"-"`
+	// VerifyToken is set while Verified is false and cleared once the user
+	// verifies their email, see auth.UserService.Verify.
+	VerifyToken string `json:"-" gorm:"index"`
+	// ResetToken is set by auth.UserService.RequestPasswordReset and
+	// cleared once the reset is confirmed.
+	ResetToken string `json:"-" gorm:"index"`
+}
+
+// UserRegister is the set of fields a client supplies to register a new
+// account, see auth.UserService.Create.
+type UserRegister struct {
+	Username   string `json:"username" binding:"required"`
+	Email      string `json:"email" binding:"required"`
+	Password   string `json:"password" binding:"required"`
+	RePassword string `json:"re_password" binding:"required"`
+}
+
+// UserLogin is the set of credentials a client supplies to authenticate,
+// see auth.UserService.Authenticate.
+type UserLogin struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
@@ -0,0 +1,100 @@
+// Copyright 2021 Ilia Frenkel. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE.txt file.
+
+// Package mail provides a transactional email sender for go-pb: account
+// verification, password reset and paste expiry/burn notifications.
+//
+// Templates live in the templates directory as plain text/template files,
+// the first line of each is the "Subject:" header, the rest is the body.
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"net/smtp"
+	"text/template"
+)
+
+//go:embed templates/*.go.txt
+var templatesFS embed.FS
+
+// MailOptions contains all the options needed to create a Mailer.
+type MailOptions struct {
+	// SMTP server host and port, ex. "smtp.example.com" and 587.
+	Host string
+	Port int
+	// Username and Password are used for SMTP PLAIN authentication, leave
+	// both empty to connect without authentication.
+	Username string
+	Password string
+	// From is the address used in the "From" header of every email sent.
+	From string
+}
+
+// Mailer sends transactional emails, either as plain text or rendered from
+// one of the named templates in the templates directory.
+type Mailer interface {
+	// Send sends a plain text email.
+	Send(to, subject, body string) error
+	// SendTemplate renders the named template with data and sends the
+	// result, name is the template file name without the templates/
+	// directory prefix, ex. "mail-verify.go.txt".
+	SendTemplate(name string, to string, data interface{}) error
+}
+
+// SMTPMailer sends emails through an SMTP server, it implements Mailer.
+//
+// Use the `New` function to create an instance of SMTPMailer.
+type SMTPMailer struct {
+	Options   MailOptions
+	templates *template.Template
+}
+
+// New returns a new SMTPMailer using opts and parses all the templates
+// embedded in the templates directory.
+func New(opts MailOptions) (*SMTPMailer, error) {
+	tpl, err := template.ParseFS(templatesFS, "templates/*.go.txt")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse mail templates: %w", err)
+	}
+
+	return &SMTPMailer{Options: opts, templates: tpl}, nil
+}
+
+// Send sends a plain text email with the given subject and body to to.
+func (m *SMTPMailer) Send(to, subject, body string) error {
+	msg := fmt.Sprintf("Subject: %s\r\n\r\n%s", subject, body)
+	return m.send(to, msg)
+}
+
+// SendTemplate renders the named template with data and sends the result
+// to to. The rendered output's first line is used as the "Subject:"
+// header, the rest becomes the body.
+func (m *SMTPMailer) SendTemplate(name string, to string, data interface{}) error {
+	var buf bytes.Buffer
+	if err := m.templates.ExecuteTemplate(&buf, name, data); err != nil {
+		return fmt.Errorf("failed to render template %s: %w", name, err)
+	}
+
+	return m.send(to, buf.String())
+}
+
+// send delivers msg, a fully formed "Subject: ...\r\n\r\nbody" message, to
+// to using net/smtp.
+func (m *SMTPMailer) send(to, msg string) error {
+	addr := fmt.Sprintf("%s:%d", m.Options.Host, m.Options.Port)
+
+	var auth smtp.Auth
+	if m.Options.Username != "" {
+		auth = smtp.PlainAuth("", m.Options.Username, m.Options.Password, m.Options.Host)
+	}
+
+	header := fmt.Sprintf("From: %s\r\nTo: %s\r\n", m.Options.From, to)
+	if err := smtp.SendMail(addr, auth, m.Options.From, []string{to}, []byte(header+msg)); err != nil {
+		return fmt.Errorf("failed to send email to %s: %w", to, err)
+	}
+
+	return nil
+}
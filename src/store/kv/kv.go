@@ -0,0 +1,32 @@
+// Copyright 2021 Ilia Frenkel. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE.txt file.
+
+// Package kv provides a minimal key-value store abstraction used for JWT
+// session revocation, request rate limiting and paste view counters. See
+// NewMemory for an in-process implementation and NewRedis for a
+// Redis/Valkey backed one.
+package kv
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned by Get when the key doesn't exist.
+var ErrNotFound = errors.New("kv: key not found")
+
+// KVStore is the minimal key-value interface required by the session
+// revocation list, the rate limiter and the paste view counter.
+type KVStore interface {
+	// Get returns the value stored at key, or ErrNotFound if it doesn't
+	// exist or has expired.
+	Get(key string) (string, error)
+	// Set stores value at key. A zero ttl means the key never expires.
+	Set(key, value string, ttl time.Duration) error
+	// Incr atomically increments the integer stored at key by one,
+	// treating a missing key as zero, and returns the new value.
+	Incr(key string) (int64, error)
+	// Expire sets a time to live on an existing key.
+	Expire(key string, ttl time.Duration) error
+}
@@ -0,0 +1,106 @@
+// Copyright 2021 Ilia Frenkel. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE.txt file.
+
+package kv
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// entry is a single value stored in MemoryStore, expiresAt is the zero
+// time when the entry never expires.
+type entry struct {
+	value     string
+	expiresAt time.Time
+}
+
+func (e entry) expired() bool {
+	return !e.expiresAt.IsZero() && time.Now().After(e.expiresAt)
+}
+
+// MemoryStore is an in-process KVStore, it is meant for tests and
+// single-instance deployments, use NewRedis for anything that needs to be
+// shared across processes.
+//
+// Use the `NewMemory` function to create an instance of MemoryStore.
+type MemoryStore struct {
+	mu   sync.Mutex
+	data map[string]entry
+}
+
+// NewMemory returns a new, empty MemoryStore.
+func NewMemory() *MemoryStore {
+	return &MemoryStore{data: make(map[string]entry)}
+}
+
+// Get returns the value stored at key, or ErrNotFound if it doesn't exist
+// or has expired.
+func (s *MemoryStore) Get(key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.data[key]
+	if !ok || e.expired() {
+		return "", ErrNotFound
+	}
+
+	return e.value, nil
+}
+
+// Set stores value at key. A zero ttl means the key never expires.
+func (s *MemoryStore) Set(key, value string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data[key] = entry{value: value, expiresAt: expiresAt(ttl)}
+
+	return nil
+}
+
+// Incr atomically increments the integer stored at key by one, treating a
+// missing or expired key as zero, and returns the new value. The key's
+// existing TTL, if any, is preserved.
+func (s *MemoryStore) Incr(key string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.data[key]
+	var n int64
+	var exp time.Time
+	if ok && !e.expired() {
+		n, _ = strconv.ParseInt(e.value, 10, 64)
+		exp = e.expiresAt
+	}
+	n++
+
+	s.data[key] = entry{value: strconv.FormatInt(n, 10), expiresAt: exp}
+
+	return n, nil
+}
+
+// Expire sets a time to live on an existing key, it is a no-op if the key
+// doesn't exist.
+func (s *MemoryStore) Expire(key string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.data[key]
+	if !ok || e.expired() {
+		return nil
+	}
+
+	e.expiresAt = expiresAt(ttl)
+	s.data[key] = e
+
+	return nil
+}
+
+func expiresAt(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(ttl)
+}
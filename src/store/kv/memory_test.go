@@ -0,0 +1,73 @@
+package kv
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_MemoryStore_SetGet(t *testing.T) {
+	t.Parallel()
+	s := NewMemory()
+
+	if err := s.Set("foo", "bar", 0); err != nil {
+		t.Fatalf("Failed to set a key: %v", err)
+	}
+
+	v, err := s.Get("foo")
+	if err != nil {
+		t.Fatalf("Failed to get a key: %v", err)
+	}
+	if v != "bar" {
+		t.Errorf("Get(\"foo\") = %q, want %q", v, "bar")
+	}
+
+	if _, err := s.Get("missing"); err != ErrNotFound {
+		t.Errorf("Get of a missing key should return ErrNotFound, got %v", err)
+	}
+}
+
+func Test_MemoryStore_Expiry(t *testing.T) {
+	t.Parallel()
+	s := NewMemory()
+
+	if err := s.Set("foo", "bar", 10*time.Millisecond); err != nil {
+		t.Fatalf("Failed to set a key: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	if _, err := s.Get("foo"); err != ErrNotFound {
+		t.Errorf("Get of an expired key should return ErrNotFound, got %v", err)
+	}
+}
+
+func Test_MemoryStore_Incr(t *testing.T) {
+	t.Parallel()
+	s := NewMemory()
+
+	for i := int64(1); i <= 3; i++ {
+		n, err := s.Incr("views")
+		if err != nil {
+			t.Fatalf("Failed to incr: %v", err)
+		}
+		if n != i {
+			t.Errorf("Incr() = %d, want %d", n, i)
+		}
+	}
+}
+
+func Test_MemoryStore_Expire(t *testing.T) {
+	t.Parallel()
+	s := NewMemory()
+
+	if _, err := s.Incr("attempts"); err != nil {
+		t.Fatalf("Failed to incr: %v", err)
+	}
+	if err := s.Expire("attempts", 10*time.Millisecond); err != nil {
+		t.Fatalf("Failed to set expiry: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	if _, err := s.Get("attempts"); err != ErrNotFound {
+		t.Errorf("Get after expiry should return ErrNotFound, got %v", err)
+	}
+}
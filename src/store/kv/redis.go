@@ -0,0 +1,64 @@
+// Copyright 2021 Ilia Frenkel. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE.txt file.
+
+package kv
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStore is a KVStore backed by Redis or a Valkey instance, use it for
+// anything that needs to be shared across processes, MemoryStore otherwise.
+//
+// Use the `NewRedis` function to create an instance of RedisStore.
+type RedisStore struct {
+	client *redis.Client
+}
+
+// NewRedis returns a RedisStore connected to addr, a host:port pair. Use
+// RedisOptions to configure authentication and the database number.
+func NewRedis(addr string, opts RedisOptions) *RedisStore {
+	return &RedisStore{client: redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Username: opts.Username,
+		Password: opts.Password,
+		DB:       opts.DB,
+	})}
+}
+
+// RedisOptions holds the connection parameters accepted by NewRedis.
+type RedisOptions struct {
+	Username string
+	Password string
+	DB       int
+}
+
+// Get returns the value stored at key, or ErrNotFound if it doesn't exist.
+func (s *RedisStore) Get(key string) (string, error) {
+	v, err := s.client.Get(context.Background(), key).Result()
+	if err == redis.Nil {
+		return "", ErrNotFound
+	}
+	return v, err
+}
+
+// Set stores value at key. A zero ttl means the key never expires.
+func (s *RedisStore) Set(key, value string, ttl time.Duration) error {
+	return s.client.Set(context.Background(), key, value, ttl).Err()
+}
+
+// Incr atomically increments the integer stored at key by one, treating a
+// missing key as zero, and returns the new value.
+func (s *RedisStore) Incr(key string) (int64, error) {
+	return s.client.Incr(context.Background(), key).Result()
+}
+
+// Expire sets a time to live on an existing key, it is a no-op if the key
+// doesn't exist.
+func (s *RedisStore) Expire(key string, ttl time.Duration) error {
+	return s.client.Expire(context.Background(), key, ttl).Err()
+}